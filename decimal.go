@@ -1,6 +1,9 @@
+//go:build !decimal256 && !decimal512
+
 package decimal
 
 import (
+	"encoding/json"
 	"fmt"
 	"math/big"
 	"strconv"
@@ -12,10 +15,54 @@ import (
 
 var Zero = NewDecimalZero()
 
-// Support only unsigned operations
+// Decimal is a fixed-point number: an unsigned mantissa magnitude, a sign,
+// and a scale (number of digits after the decimal point). This is the
+// default backend, good for values up to 256 bits of unsigned magnitude;
+// build with the decimal256 or decimal512 tag to swap in a wider backend.
 type Decimal struct {
 	value    *uint256.Int
 	mantissa uint8
+	neg      bool
+}
+
+// ExpScale returns 10^n as a *uint256.Int. Negative n is treated as zero,
+// matching the scale-widening call sites below, which never pass a
+// negative exponent on purpose.
+func ExpScale(n int16) *uint256.Int {
+	if n <= 0 {
+		return uint256.NewInt(1)
+	}
+	return new(uint256.Int).Exp(uint256.NewInt(10), uint256.NewInt(uint64(n)))
+}
+
+// expScaleInto sets dst to 10^n, like ExpScale, but writes into dst instead
+// of allocating a fresh *uint256.Int, so the zero-allocation hot paths
+// (DivTo, RescaleInPlace, CmpNoAlloc) can scale a scratch value without
+// escaping to the heap.
+func expScaleInto(dst *uint256.Int, n int16) {
+	if n <= 0 {
+		dst.SetOne()
+		return
+	}
+	var base, exp uint256.Int
+	base.SetUint64(10)
+	exp.SetUint64(uint64(n))
+	dst.Exp(&base, &exp)
+}
+
+// FormatUint256 renders value as an unsigned decimal string with scale
+// digits after the decimal point.
+func FormatUint256(value *uint256.Int, scale int) string {
+	s := value.Dec()
+	if scale <= 0 {
+		return s
+	}
+
+	for len(s) <= scale {
+		s = "0" + s
+	}
+
+	return s[:len(s)-scale] + "." + s[len(s)-scale:]
 }
 
 func (d *Decimal) MarshalJSON() ([]byte, error) {
@@ -35,8 +82,8 @@ func (d *Decimal) UnmarshalJSON(dataJson []byte) error {
 	return nil
 }
 
-// return d == y
-func (d *Decimal) Eq(y *Decimal) bool {
+// cmp returns -1, 0, or 1 as d is less than, equal to, or greater than y.
+func (d *Decimal) cmp(y *Decimal) int {
 	xx := NewDecimal(d)
 	yy := NewDecimal(y)
 
@@ -46,25 +93,41 @@ func (d *Decimal) Eq(y *Decimal) bool {
 		yy.Rescale(xx.mantissa)
 	}
 
-	return xx.value.Eq(yy.value)
-}
+	if xx.IsZero() && yy.IsZero() {
+		return 0
+	}
 
-// return d > y
-func (d *Decimal) Gt(y *Decimal) bool {
-	xx := NewDecimal(d)
-	yy := NewDecimal(y)
+	if xx.neg != yy.neg {
+		if xx.neg {
+			return -1
+		}
+		return 1
+	}
 
-	if yy.mantissa > xx.mantissa {
-		xx.Rescale(yy.mantissa)
-	} else if yy.mantissa < xx.mantissa {
-		yy.Rescale(xx.mantissa)
+	c := xx.value.Cmp(yy.value)
+	if xx.neg {
+		return -c
 	}
+	return c
+}
+
+// return d == y
+func (d *Decimal) Eq(y *Decimal) bool {
+	return d.cmp(y) == 0
+}
 
-	return xx.value.Gt(yy.value)
+// return d > y
+func (d *Decimal) Gt(y *Decimal) bool {
+	return d.cmp(y) > 0
 }
 
 // return d < y
 func (d *Decimal) Lt(y *Decimal) bool {
+	return d.cmp(y) < 0
+}
+
+// CmpAbs compares |d| and |y|, ignoring sign, and returns -1, 0, or 1.
+func (d *Decimal) CmpAbs(y *Decimal) int {
 	xx := NewDecimal(d)
 	yy := NewDecimal(y)
 
@@ -74,7 +137,32 @@ func (d *Decimal) Lt(y *Decimal) bool {
 		yy.Rescale(xx.mantissa)
 	}
 
-	return xx.value.Lt(yy.value)
+	return xx.value.Cmp(yy.value)
+}
+
+// Neg sets d to -d and returns d.
+func (d *Decimal) Neg() *Decimal {
+	if !d.value.IsZero() {
+		d.neg = !d.neg
+	}
+	return d
+}
+
+// Abs sets d to |d| and returns d.
+func (d *Decimal) Abs() *Decimal {
+	d.neg = false
+	return d
+}
+
+// Sign returns -1 if d < 0, 0 if d == 0, and 1 if d > 0.
+func (d *Decimal) Sign() int {
+	if d.value.IsZero() {
+		return 0
+	}
+	if d.neg {
+		return -1
+	}
+	return 1
 }
 
 // d = d + y and return d
@@ -88,14 +176,29 @@ func (d *Decimal) Add(y *Decimal) *Decimal {
 		yy.Rescale(xx.mantissa)
 	}
 
-	d.value.Add(xx.value, yy.value)
+	if xx.neg == yy.neg {
+		d.value.Add(xx.value, yy.value)
+		d.neg = xx.neg
+	} else if xx.value.Cmp(yy.value) >= 0 {
+		d.value.Sub(xx.value, yy.value)
+		d.neg = xx.neg
+	} else {
+		d.value.Sub(yy.value, xx.value)
+		d.neg = yy.neg
+	}
 	d.mantissa = xx.mantissa
+	d.normalizeSign()
 
 	return d
 }
 
 // d = d - y and return d
 func (d *Decimal) Sub(y *Decimal) *Decimal {
+	return d.Add(NewDecimal(y).Neg())
+}
+
+// d = d * y and return d
+func (d *Decimal) Mul(y *Decimal) *Decimal {
 	xx := NewDecimal(d)
 	yy := NewDecimal(y)
 
@@ -105,14 +208,17 @@ func (d *Decimal) Sub(y *Decimal) *Decimal {
 		yy.Rescale(xx.mantissa)
 	}
 
-	d.value.Sub(xx.value, yy.value)
-	d.mantissa = xx.mantissa
+	d.value.Mul(xx.value, yy.value)
+	d.mantissa = xx.mantissa + yy.mantissa
+	d.neg = xx.neg != yy.neg
+	d.normalizeSign()
 
 	return d
 }
 
-// d = d * y and return d
-func (d *Decimal) Mul(y *Decimal) *Decimal {
+// MulChecked returns d * y and true, or (nil, false) if the mantissa
+// multiplication overflows the backend's 256-bit unsigned magnitude.
+func (d *Decimal) MulChecked(y *Decimal) (*Decimal, bool) {
 	xx := NewDecimal(d)
 	yy := NewDecimal(y)
 
@@ -122,16 +228,27 @@ func (d *Decimal) Mul(y *Decimal) *Decimal {
 		yy.Rescale(xx.mantissa)
 	}
 
-	d.value.Mul(xx.value, yy.value)
-	d.mantissa = xx.mantissa + yy.mantissa
+	value := new(uint256.Int)
+	_, overflow := value.MulOverflow(xx.value, yy.value)
+	if overflow {
+		return nil, false
+	}
 
-	return d
+	result := &Decimal{
+		value:    value,
+		mantissa: xx.mantissa + yy.mantissa,
+		neg:      xx.neg != yy.neg,
+	}
+	result.normalizeSign()
+
+	return result, true
 }
 
 const defaultDivScale = 20
 
-// d = d / y and return d
-func (d *Decimal) Div(y *Decimal) *Decimal {
+// d = d / y, rounded to defaultDivScale digits using mode (RoundDown if omitted), and return d.
+// If d's scale exceeds y's by defaultDivScale or more, the result keeps d's scale instead.
+func (d *Decimal) Div(y *Decimal, mode ...RoundingMode) *Decimal {
 	if y.Eq(Zero) {
 		return NewDecimalZero()
 	}
@@ -147,19 +264,101 @@ func (d *Decimal) Div(y *Decimal) *Decimal {
 		xx.value.Mul(xx.value, ExpScale(int16(-e)))
 		scalerest = defaultDivScale
 	} else {
-		yy.value.Mul(yy.value, ExpScale(int16(e)))
+		xx.value.Mul(xx.value, ExpScale(int16(yy.mantissa)))
 		scalerest = xx.mantissa
 	}
 
-	d.value.Div(xx.value, yy.value)
+	neg := xx.neg != yy.neg
+	quotient := new(uint256.Int)
+	remainder := new(uint256.Int)
+	quotient.DivMod(xx.value, yy.value, remainder)
+
+	if roundUp(pickRoundingMode(mode), neg, remainder, yy.value, quotient) {
+		quotient.AddUint64(quotient, 1)
+	}
+
+	d.value = quotient
 	d.mantissa = scalerest
+	d.neg = neg
+	d.normalizeSign()
 
 	return d
 }
 
+// Round sets d to d rounded to scale digits after the decimal point using mode, and returns d.
+func (d *Decimal) Round(scale uint8, mode RoundingMode) *Decimal {
+	if d == nil {
+		return nil
+	}
+
+	if scale >= d.mantissa {
+		return d.Rescale(scale)
+	}
+
+	divisor := ExpScale(int16(d.mantissa - scale))
+	quotient := new(uint256.Int)
+	remainder := new(uint256.Int)
+	quotient.DivMod(d.value, divisor, remainder)
+
+	if roundUp(mode, d.neg, remainder, divisor, quotient) {
+		quotient.AddUint64(quotient, 1)
+	}
+
+	d.value = quotient
+	d.mantissa = scale
+	d.normalizeSign()
+
+	return d
+}
+
+// roundUp reports whether the integer division quotient = value/divisor (with the
+// given remainder) should be incremented by one to honor mode for a value whose
+// sign is neg.
+func roundUp(mode RoundingMode, neg bool, remainder, divisor, quotient *uint256.Int) bool {
+	if remainder.IsZero() {
+		return false
+	}
+
+	switch mode {
+	case RoundDown:
+		return false
+	case RoundUp:
+		return true
+	case RoundCeiling:
+		return !neg
+	case RoundFloor:
+		return neg
+	case RoundHalfUp, RoundHalfDown, RoundHalfEven:
+		twiceRemainder := new(uint256.Int).Lsh(remainder, 1)
+		c := twiceRemainder.Cmp(divisor)
+		switch mode {
+		case RoundHalfUp:
+			return c >= 0
+		case RoundHalfDown:
+			return c > 0
+		default: // RoundHalfEven
+			if c != 0 {
+				return c > 0
+			}
+			return new(uint256.Int).And(quotient, uint256.NewInt(1)).Eq(uint256.NewInt(1))
+		}
+	default:
+		return false
+	}
+}
+
 func (d *Decimal) SetFromBig(value *big.Int, mantissa uint8) (*Decimal, bool) {
-	overflow := d.value.SetFromBig(value)
+	neg := value.Sign() < 0
+	abs := value
+	if neg {
+		abs = new(big.Int).Abs(value)
+	}
+
+	overflow := d.value.SetFromBig(abs)
 	d.SetMantissa(mantissa)
+	d.neg = neg
+	d.normalizeSign()
+
 	return d, overflow
 }
 
@@ -182,11 +381,25 @@ func (d *Decimal) GetMantissa() uint8 {
 }
 
 func (d *Decimal) FromString(value string) bool {
+	if d.value == nil {
+		d.value = new(uint256.Int)
+	}
+
 	if value == "" {
-		d.value = uint256.NewInt(0)
+		d.value.Clear()
+		d.neg = false
 		return true
 	}
 
+	neg := false
+	switch value[0] {
+	case '-':
+		neg = true
+		value = value[1:]
+	case '+':
+		value = value[1:]
+	}
+
 	var ok bool
 	var mantissa uint8 = 0
 	var valBig = new(big.Int)
@@ -220,11 +433,15 @@ func (d *Decimal) FromString(value string) bool {
 		return false
 	}
 	d.mantissa = mantissa
+	d.neg = neg
+	d.normalizeSign()
 
 	return true
 }
 
-func (d *Decimal) Rescale(mantissa uint8) *Decimal {
+// Rescale changes d's scale to mantissa, rounding dropped digits using mode
+// (RoundDown if omitted), and returns d.
+func (d *Decimal) Rescale(mantissa uint8, mode ...RoundingMode) *Decimal {
 	if d == nil {
 		return nil
 	}
@@ -240,17 +457,205 @@ func (d *Decimal) Rescale(mantissa uint8) *Decimal {
 		return d
 	}
 
-	if mantissa < d.mantissa {
-		d.value.Div(d.value, ExpScale(int16(d.mantissa-mantissa)))
+	return d.Round(mantissa, pickRoundingMode(mode))
+}
+
+// RescaleInPlace is Rescale without the Round/Rescale helper's intermediate
+// *uint256.Int allocations; it rounds dropped digits using mode (RoundDown
+// if omitted) directly into d's own backing value.
+func (d *Decimal) RescaleInPlace(mantissa uint8, mode ...RoundingMode) *Decimal {
+	if d == nil || mantissa == d.mantissa {
+		return d
+	}
+
+	var scale uint256.Int
+	if mantissa > d.mantissa {
+		expScaleInto(&scale, int16(mantissa-d.mantissa))
+		d.value.Mul(d.value, &scale)
 		d.mantissa = mantissa
 		return d
 	}
 
+	expScaleInto(&scale, int16(d.mantissa-mantissa))
+	var quotient, remainder uint256.Int
+	quotient.DivMod(d.value, &scale, &remainder)
+
+	if roundUp(pickRoundingMode(mode), d.neg, &remainder, &scale, &quotient) {
+		quotient.AddUint64(&quotient, 1)
+	}
+
+	d.value.Set(&quotient)
+	d.mantissa = mantissa
+	d.normalizeSign()
+
+	return d
+}
+
+// setSignedSum writes the signed sum of magnitudes xv and yv (with signs
+// xneg/yneg and common scale) into d. It backs AddTo and SubTo.
+func setSignedSum(d *Decimal, xv, yv *uint256.Int, xneg, yneg bool, scale uint8) *Decimal {
+	if xneg == yneg {
+		d.value.Add(xv, yv)
+		d.neg = xneg
+	} else if xv.Cmp(yv) >= 0 {
+		d.value.Sub(xv, yv)
+		d.neg = xneg
+	} else {
+		d.value.Sub(yv, xv)
+		d.neg = yneg
+	}
+	d.mantissa = scale
+	d.normalizeSign()
+
+	return d
+}
+
+// AddTo sets d = x + y without allocating, leaving x and y unmodified, and returns d.
+func (d *Decimal) AddTo(x, y *Decimal) *Decimal {
+	var xv, yv, scale uint256.Int
+	xv.Set(x.value)
+	yv.Set(y.value)
+
+	xm, ym := x.mantissa, y.mantissa
+	if ym > xm {
+		expScaleInto(&scale, int16(ym-xm))
+		xv.Mul(&xv, &scale)
+		xm = ym
+	} else if ym < xm {
+		expScaleInto(&scale, int16(xm-ym))
+		yv.Mul(&yv, &scale)
+	}
+
+	return setSignedSum(d, &xv, &yv, x.neg, y.neg, xm)
+}
+
+// SubTo sets d = x - y without allocating, leaving x and y unmodified, and returns d.
+func (d *Decimal) SubTo(x, y *Decimal) *Decimal {
+	var xv, yv, scale uint256.Int
+	xv.Set(x.value)
+	yv.Set(y.value)
+
+	xm, ym := x.mantissa, y.mantissa
+	if ym > xm {
+		expScaleInto(&scale, int16(ym-xm))
+		xv.Mul(&xv, &scale)
+		xm = ym
+	} else if ym < xm {
+		expScaleInto(&scale, int16(xm-ym))
+		yv.Mul(&yv, &scale)
+	}
+
+	return setSignedSum(d, &xv, &yv, x.neg, !y.neg, xm)
+}
+
+// MulTo sets d = x * y without allocating, leaving x and y unmodified, and returns d.
+func (d *Decimal) MulTo(x, y *Decimal) *Decimal {
+	var xv, yv, scale uint256.Int
+	xv.Set(x.value)
+	yv.Set(y.value)
+
+	xm, ym := x.mantissa, y.mantissa
+	if ym > xm {
+		expScaleInto(&scale, int16(ym-xm))
+		xv.Mul(&xv, &scale)
+		xm = ym
+	} else if ym < xm {
+		expScaleInto(&scale, int16(xm-ym))
+		yv.Mul(&yv, &scale)
+		ym = xm
+	}
+
+	d.value.Mul(&xv, &yv)
+	d.mantissa = xm + ym
+	d.neg = x.neg != y.neg
+	d.normalizeSign()
+
+	return d
+}
+
+// DivTo sets d = x / y, rounded to defaultDivScale digits using mode (RoundDown
+// if omitted), or to x's scale if that exceeds y's by defaultDivScale or more,
+// without allocating. x and y are left unmodified, and d is returned.
+func (d *Decimal) DivTo(x, y *Decimal, mode ...RoundingMode) *Decimal {
+	if y.IsZero() {
+		d.value.SetUint64(0)
+		d.mantissa = 0
+		d.neg = false
+		return d
+	}
+
+	var xv, yv, scale uint256.Int
+	xv.Set(x.value)
+	yv.Set(y.value)
+
+	var scalerest uint8
+	e := int64(x.mantissa) - int64(y.mantissa) - int64(defaultDivScale)
+	if e < 0 {
+		expScaleInto(&scale, int16(-e))
+		xv.Mul(&xv, &scale)
+		scalerest = defaultDivScale
+	} else {
+		expScaleInto(&scale, int16(y.mantissa))
+		xv.Mul(&xv, &scale)
+		scalerest = x.mantissa
+	}
+
+	neg := x.neg != y.neg
+	var quotient, remainder uint256.Int
+	quotient.DivMod(&xv, &yv, &remainder)
+
+	if roundUp(pickRoundingMode(mode), neg, &remainder, &yv, &quotient) {
+		quotient.AddUint64(&quotient, 1)
+	}
+
+	d.value.Set(&quotient)
+	d.mantissa = scalerest
+	d.neg = neg
+	d.normalizeSign()
+
 	return d
 }
 
+// CmpNoAlloc compares d and y like cmp, but rescales into stack-allocated
+// uint256.Int values instead of copying through NewDecimal.
+func (d *Decimal) CmpNoAlloc(y *Decimal) int {
+	var xv, yv, scale uint256.Int
+	xv.Set(d.value)
+	yv.Set(y.value)
+
+	xm, ym := d.mantissa, y.mantissa
+	if ym > xm {
+		expScaleInto(&scale, int16(ym-xm))
+		xv.Mul(&xv, &scale)
+	} else if ym < xm {
+		expScaleInto(&scale, int16(xm-ym))
+		yv.Mul(&yv, &scale)
+	}
+
+	if xv.IsZero() && yv.IsZero() {
+		return 0
+	}
+
+	if d.neg != y.neg {
+		if d.neg {
+			return -1
+		}
+		return 1
+	}
+
+	c := xv.Cmp(&yv)
+	if d.neg {
+		return -c
+	}
+	return c
+}
+
 func (d *Decimal) ToBig() *big.Int {
-	return d.value.ToBig()
+	b := d.value.ToBig()
+	if d.neg {
+		b.Neg(b)
+	}
+	return b
 }
 
 func (d *Decimal) String() string {
@@ -258,13 +663,33 @@ func (d *Decimal) String() string {
 		return "0"
 	}
 
-	return FormatUint256(d.value, int(d.mantissa))
+	s := FormatUint256(d.value, int(d.mantissa))
+	if d.neg && !d.value.IsZero() {
+		return "-" + s
+	}
+	return s
 }
 
 func (d *Decimal) IsZero() bool {
 	return d.value.IsZero()
 }
 
+// Reset zeroes d in place for reuse (e.g. after Acquire from the scratch
+// pool) and returns d.
+func (d *Decimal) Reset() *Decimal {
+	d.value.SetUint64(0)
+	d.mantissa = 0
+	d.neg = false
+	return d
+}
+
+// normalizeSign clears the sign on a zero value so that -0 and 0 compare and print alike.
+func (d *Decimal) normalizeSign() {
+	if d.value.IsZero() {
+		d.neg = false
+	}
+}
+
 func NewDecimalFromUint256(value *uint256.Int, mantissa uint8) *Decimal {
 	valueCopy := uint256.NewInt(0)
 	copy(valueCopy[:], value[:4])
@@ -275,19 +700,61 @@ func NewDecimalFromUint256(value *uint256.Int, mantissa uint8) *Decimal {
 	}
 }
 
+// NewDecimalFromInt256 interprets value as a two's-complement signed 256-bit
+// integer (as produced by Solidity's int256 or an RLP-decoded signed field)
+// and builds the equivalent Decimal.
+func NewDecimalFromInt256(value *uint256.Int, mantissa uint8) *Decimal {
+	b := value.ToBig()
+
+	signBit := new(big.Int).Lsh(big.NewInt(1), 255)
+	if b.Cmp(signBit) >= 0 {
+		modulus := new(big.Int).Lsh(big.NewInt(1), 256)
+		b = new(big.Int).Sub(b, modulus)
+	}
+
+	return NewDecimalFromBig(b, mantissa)
+}
+
+// FromBytes builds a Decimal from a big-endian unsigned magnitude, as found
+// on the wire in binary/RLP/ABI encodings. It fails if data is longer than
+// the backend's word size.
+func FromBytes(data []byte, mantissa uint8) (*Decimal, bool) {
+	if len(data) > 32 {
+		return nil, false
+	}
+
+	value := new(uint256.Int).SetBytes(data)
+
+	return &Decimal{
+		value:    value,
+		mantissa: mantissa,
+	}, true
+}
+
 func NewDecimalFromBig(value *big.Int, mantissa uint8) *Decimal {
 	if value == nil {
 		value = new(big.Int)
 	}
-	valueUint256, overflow := uint256.FromBig(value)
+
+	neg := value.Sign() < 0
+	abs := value
+	if neg {
+		abs = new(big.Int).Abs(value)
+	}
+
+	valueUint256, overflow := uint256.FromBig(abs)
 	if overflow {
 		return NewDecimalZero()
 	}
 
-	return &Decimal{
+	d := &Decimal{
 		value:    valueUint256,
 		mantissa: mantissa,
+		neg:      neg,
 	}
+	d.normalizeSign()
+
+	return d
 }
 
 func NewDecimalFromUint64(value uint64) *Decimal {
@@ -327,5 +794,9 @@ func NewDecimal(decimal *Decimal) *Decimal {
 	if decimal == nil {
 		decimal = NewDecimalZero()
 	}
-	return NewDecimalFromUint256(decimal.value, decimal.mantissa)
+
+	d := NewDecimalFromUint256(decimal.value, decimal.mantissa)
+	d.neg = decimal.neg
+
+	return d
 }
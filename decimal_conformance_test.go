@@ -0,0 +1,168 @@
+package decimal
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// These tests exercise only the package's exported surface, so the same
+// file runs unmodified against the default backend or, built with
+// -tags decimal256 / -tags decimal512, the wide backends.
+
+func mustDecimal(t *testing.T, s string) *Decimal {
+	t.Helper()
+	d, ok := NewDecimalFromString(s)
+	if !ok {
+		t.Fatalf("NewDecimalFromString(%q) failed", s)
+	}
+	return d
+}
+
+func assertClose(t *testing.T, got *Decimal, want string, tolerance string) {
+	t.Helper()
+	w := mustDecimal(t, want)
+	tol := mustDecimal(t, tolerance)
+	diff := NewDecimal(got).Sub(w).Abs()
+	if diff.Gt(tol) {
+		t.Fatalf("got %s, want %s (+/- %s)", got.String(), want, tolerance)
+	}
+}
+
+func TestFromStringSignRoundTrip(t *testing.T) {
+	cases := []struct {
+		in   string
+		sign int
+	}{
+		{"0", 0},
+		{"123.45", 1},
+		{"-123.45", -1},
+		{"-0", 0},
+		{"-0.00", 0},
+	}
+	for _, c := range cases {
+		d := mustDecimal(t, c.in)
+		if got := d.Sign(); got != c.sign {
+			t.Errorf("Sign(%q) = %d, want %d", c.in, got, c.sign)
+		}
+	}
+}
+
+func TestNegAbs(t *testing.T) {
+	d := mustDecimal(t, "-5.5")
+	if d.Sign() != -1 {
+		t.Fatalf("expected negative")
+	}
+
+	abs := NewDecimal(d).Abs()
+	if abs.Sign() != 1 || !abs.Eq(mustDecimal(t, "5.5")) {
+		t.Errorf("Abs() = %s, want 5.5", abs.String())
+	}
+
+	negated := NewDecimal(abs).Neg()
+	if !negated.Eq(d) {
+		t.Errorf("Neg() = %s, want %s", negated.String(), d.String())
+	}
+}
+
+func TestAddSubMulDivIdentities(t *testing.T) {
+	a := mustDecimal(t, "12.34")
+	b := mustDecimal(t, "-5.6")
+
+	sum := NewDecimal(a).Add(b)
+	back := NewDecimal(sum).Sub(b)
+	if !back.Eq(a) {
+		t.Errorf("(a+b)-b = %s, want %s", back.String(), a.String())
+	}
+
+	product := NewDecimal(a).Mul(b)
+	quotient := NewDecimal(product).Div(b)
+	assertClose(t, quotient, "12.34", "0.0000000001")
+}
+
+func TestCmpAbsAndCompare(t *testing.T) {
+	neg := mustDecimal(t, "-7")
+	pos := mustDecimal(t, "3")
+
+	if neg.CmpAbs(pos) <= 0 {
+		t.Errorf("|-7| should be > |3|")
+	}
+	if !neg.Lt(pos) {
+		t.Errorf("-7 should be < 3")
+	}
+	if !pos.Gt(neg) {
+		t.Errorf("3 should be > -7")
+	}
+}
+
+func TestRoundingModes(t *testing.T) {
+	cases := []struct {
+		mode RoundingMode
+		in   string
+		want string
+	}{
+		{RoundDown, "2.5", "2"},
+		{RoundUp, "2.5", "3"},
+		{RoundHalfUp, "2.5", "3"},
+		{RoundHalfDown, "2.5", "2"},
+		{RoundHalfEven, "2.5", "2"},
+		{RoundHalfEven, "3.5", "4"},
+		{RoundCeiling, "2.5", "3"},
+		{RoundCeiling, "-2.5", "-2"},
+		{RoundFloor, "2.5", "2"},
+		{RoundFloor, "-2.5", "-3"},
+	}
+	for _, c := range cases {
+		got := NewDecimal(mustDecimal(t, c.in)).Round(0, c.mode)
+		if !got.Eq(mustDecimal(t, c.want)) {
+			t.Errorf("Round(%s, %s) = %s, want %s", c.in, c.mode, got.String(), c.want)
+		}
+	}
+}
+
+func TestMulChecked(t *testing.T) {
+	a := mustDecimal(t, "2")
+	b := mustDecimal(t, "3")
+
+	result, ok := a.MulChecked(b)
+	if !ok {
+		t.Fatalf("MulChecked(2, 3) unexpectedly overflowed")
+	}
+	if !result.Eq(mustDecimal(t, "6")) {
+		t.Errorf("MulChecked(2, 3) = %s, want 6", result.String())
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	d := mustDecimal(t, "-42.125")
+
+	data, err := json.Marshal(d)
+	if err != nil {
+		t.Fatalf("MarshalJSON: %v", err)
+	}
+
+	var out Decimal
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("UnmarshalJSON: %v", err)
+	}
+	if !out.Eq(d) {
+		t.Errorf("round-tripped %s, want %s", out.String(), d.String())
+	}
+}
+
+func TestSqrtExpLnPow(t *testing.T) {
+	assertClose(t, NewDecimal(mustDecimal(t, "4")).Sqrt(), "2", "0.0000000001")
+	assertClose(t, NewDecimal(mustDecimal(t, "2")).Sqrt(), "1.41421356", "0.00000001")
+
+	assertClose(t, NewDecimal(mustDecimal(t, "0")).Exp(), "1", "0.0000000001")
+	assertClose(t, NewDecimal(mustDecimal(t, "1")).Exp(), "2.718281828", "0.000000001")
+
+	assertClose(t, NewDecimal(mustDecimal(t, "1")).Ln(), "0", "0.0000000001")
+	assertClose(t, NewDecimal(mustDecimal(t, "2.718281828")).Ln(), "1", "0.000000001")
+
+	assertClose(t, NewDecimal(mustDecimal(t, "2")).Pow(mustDecimal(t, "10")), "1024", "0.000001")
+
+	powInt := NewDecimal(mustDecimal(t, "2")).PowInt(10)
+	if !powInt.Eq(mustDecimal(t, "1024")) {
+		t.Errorf("PowInt(2, 10) = %s, want 1024", powInt.String())
+	}
+}
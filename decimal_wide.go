@@ -0,0 +1,638 @@
+//go:build decimal256 || decimal512
+
+package decimal
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/holiman/uint256"
+)
+
+var Zero = NewDecimalZero()
+
+// Decimal is a fixed-point number: a signed mantissa and a scale (number of
+// digits after the decimal point). This is the wide backend, selected by the
+// decimal256/decimal512 build tags, backed by a math/big.Int so that Mul
+// between two large-mantissa values doesn't silently overflow like the
+// default uint256 backend does. The wideBits signed range is enforced when
+// parsing or building a Decimal and by MulChecked; Add, Sub, Mul and Div
+// results are not checked against it.
+type Decimal struct {
+	value    *big.Int
+	mantissa uint8
+}
+
+// fitsWide reports whether v fits in a signed wideBits-bit integer.
+func fitsWide(v *big.Int) bool {
+	limit := new(big.Int).Lsh(big.NewInt(1), wideBits-1)
+	return v.Cmp(new(big.Int).Neg(limit)) >= 0 && v.Cmp(limit) < 0
+}
+
+func expScaleBig(n int16) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+func formatBig(value *big.Int, scale int) string {
+	neg := value.Sign() < 0
+	s := new(big.Int).Abs(value).String()
+
+	if scale <= 0 {
+		if neg {
+			return "-" + s
+		}
+		return s
+	}
+
+	for len(s) <= scale {
+		s = "0" + s
+	}
+
+	out := s[:len(s)-scale] + "." + s[len(s)-scale:]
+	if neg {
+		out = "-" + out
+	}
+	return out
+}
+
+func (d *Decimal) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+func (d *Decimal) UnmarshalJSON(dataJson []byte) error {
+	var data string
+	if err := json.Unmarshal(dataJson, &data); err != nil {
+		return fmt.Errorf("error unmarshal decimal: %s: %w", string(dataJson), err)
+	}
+
+	if !d.FromString(data) {
+		return fmt.Errorf("error unmarshal decimal: %s", data)
+	}
+
+	return nil
+}
+
+// cmp returns -1, 0, or 1 as d is less than, equal to, or greater than y.
+func (d *Decimal) cmp(y *Decimal) int {
+	xx := NewDecimal(d)
+	yy := NewDecimal(y)
+
+	if yy.mantissa > xx.mantissa {
+		xx.Rescale(yy.mantissa)
+	} else if yy.mantissa < xx.mantissa {
+		yy.Rescale(xx.mantissa)
+	}
+
+	return xx.value.Cmp(yy.value)
+}
+
+// return d == y
+func (d *Decimal) Eq(y *Decimal) bool {
+	return d.cmp(y) == 0
+}
+
+// return d > y
+func (d *Decimal) Gt(y *Decimal) bool {
+	return d.cmp(y) > 0
+}
+
+// return d < y
+func (d *Decimal) Lt(y *Decimal) bool {
+	return d.cmp(y) < 0
+}
+
+// CmpAbs compares |d| and |y|, ignoring sign, and returns -1, 0, or 1.
+func (d *Decimal) CmpAbs(y *Decimal) int {
+	xx := NewDecimal(d)
+	yy := NewDecimal(y)
+
+	if yy.mantissa > xx.mantissa {
+		xx.Rescale(yy.mantissa)
+	} else if yy.mantissa < xx.mantissa {
+		yy.Rescale(xx.mantissa)
+	}
+
+	return new(big.Int).Abs(xx.value).Cmp(new(big.Int).Abs(yy.value))
+}
+
+// Neg sets d to -d and returns d.
+func (d *Decimal) Neg() *Decimal {
+	d.value.Neg(d.value)
+	return d
+}
+
+// Abs sets d to |d| and returns d.
+func (d *Decimal) Abs() *Decimal {
+	d.value.Abs(d.value)
+	return d
+}
+
+// Sign returns -1 if d < 0, 0 if d == 0, and 1 if d > 0.
+func (d *Decimal) Sign() int {
+	return d.value.Sign()
+}
+
+// d = d + y and return d
+func (d *Decimal) Add(y *Decimal) *Decimal {
+	xx := NewDecimal(d)
+	yy := NewDecimal(y)
+
+	if yy.mantissa > xx.mantissa {
+		xx.Rescale(yy.mantissa)
+	} else if yy.mantissa < xx.mantissa {
+		yy.Rescale(xx.mantissa)
+	}
+
+	d.value = new(big.Int).Add(xx.value, yy.value)
+	d.mantissa = xx.mantissa
+
+	return d
+}
+
+// d = d - y and return d
+func (d *Decimal) Sub(y *Decimal) *Decimal {
+	xx := NewDecimal(d)
+	yy := NewDecimal(y)
+
+	if yy.mantissa > xx.mantissa {
+		xx.Rescale(yy.mantissa)
+	} else if yy.mantissa < xx.mantissa {
+		yy.Rescale(xx.mantissa)
+	}
+
+	d.value = new(big.Int).Sub(xx.value, yy.value)
+	d.mantissa = xx.mantissa
+
+	return d
+}
+
+// d = d * y and return d
+func (d *Decimal) Mul(y *Decimal) *Decimal {
+	xx := NewDecimal(d)
+	yy := NewDecimal(y)
+
+	if yy.mantissa > xx.mantissa {
+		xx.Rescale(yy.mantissa)
+	} else if yy.mantissa < xx.mantissa {
+		yy.Rescale(xx.mantissa)
+	}
+
+	d.value = new(big.Int).Mul(xx.value, yy.value)
+	d.mantissa = xx.mantissa + yy.mantissa
+
+	return d
+}
+
+// MulChecked returns d * y and true, or (nil, false) if the mantissa
+// multiplication overflows the backend's signed wideBits-bit range.
+func (d *Decimal) MulChecked(y *Decimal) (*Decimal, bool) {
+	xx := NewDecimal(d)
+	yy := NewDecimal(y)
+
+	if yy.mantissa > xx.mantissa {
+		xx.Rescale(yy.mantissa)
+	} else if yy.mantissa < xx.mantissa {
+		yy.Rescale(xx.mantissa)
+	}
+
+	value := new(big.Int).Mul(xx.value, yy.value)
+	if !fitsWide(value) {
+		return nil, false
+	}
+
+	return &Decimal{value: value, mantissa: xx.mantissa + yy.mantissa}, true
+}
+
+const defaultDivScale = 20
+
+// d = d / y, rounded to defaultDivScale digits using mode (RoundDown if omitted), and return d.
+// If d's scale exceeds y's by defaultDivScale or more, the result keeps d's scale instead.
+func (d *Decimal) Div(y *Decimal, mode ...RoundingMode) *Decimal {
+	if y.Eq(Zero) {
+		return NewDecimalZero()
+	}
+
+	xx := NewDecimal(d)
+	yy := NewDecimal(y)
+
+	var scalerest uint8
+	e := int64(xx.mantissa) - int64(yy.mantissa) - int64(defaultDivScale)
+	// todo: check overflow uint8
+
+	if e < 0 {
+		xx.value.Mul(xx.value, expScaleBig(int16(-e)))
+		scalerest = defaultDivScale
+	} else {
+		xx.value.Mul(xx.value, expScaleBig(int16(yy.mantissa)))
+		scalerest = xx.mantissa
+	}
+
+	neg := (xx.value.Sign() < 0) != (yy.value.Sign() < 0)
+	xxAbs := new(big.Int).Abs(xx.value)
+	yyAbs := new(big.Int).Abs(yy.value)
+
+	quotient := new(big.Int)
+	remainder := new(big.Int)
+	quotient.QuoRem(xxAbs, yyAbs, remainder)
+
+	if roundUp(pickRoundingMode(mode), neg, remainder, yyAbs, quotient) {
+		quotient.Add(quotient, big.NewInt(1))
+	}
+	if neg {
+		quotient.Neg(quotient)
+	}
+
+	d.value = quotient
+	d.mantissa = scalerest
+
+	return d
+}
+
+// Round sets d to d rounded to scale digits after the decimal point using mode, and returns d.
+func (d *Decimal) Round(scale uint8, mode RoundingMode) *Decimal {
+	if d == nil {
+		return nil
+	}
+
+	if scale >= d.mantissa {
+		return d.Rescale(scale)
+	}
+
+	divisor := expScaleBig(int16(d.mantissa - scale))
+	neg := d.value.Sign() < 0
+	abs := new(big.Int).Abs(d.value)
+
+	quotient := new(big.Int)
+	remainder := new(big.Int)
+	quotient.QuoRem(abs, divisor, remainder)
+
+	if roundUp(mode, neg, remainder, divisor, quotient) {
+		quotient.Add(quotient, big.NewInt(1))
+	}
+	if neg {
+		quotient.Neg(quotient)
+	}
+
+	d.value = quotient
+	d.mantissa = scale
+
+	return d
+}
+
+// roundUp reports whether the integer division quotient = value/divisor (with the
+// given remainder) should be incremented by one to honor mode for a value whose
+// sign is neg.
+func roundUp(mode RoundingMode, neg bool, remainder, divisor, quotient *big.Int) bool {
+	if remainder.Sign() == 0 {
+		return false
+	}
+
+	switch mode {
+	case RoundDown:
+		return false
+	case RoundUp:
+		return true
+	case RoundCeiling:
+		return !neg
+	case RoundFloor:
+		return neg
+	case RoundHalfUp, RoundHalfDown, RoundHalfEven:
+		c := new(big.Int).Lsh(remainder, 1).Cmp(divisor)
+		switch mode {
+		case RoundHalfUp:
+			return c >= 0
+		case RoundHalfDown:
+			return c > 0
+		default: // RoundHalfEven
+			if c != 0 {
+				return c > 0
+			}
+			return quotient.Bit(0) == 1
+		}
+	default:
+		return false
+	}
+}
+
+func (d *Decimal) SetFromBig(value *big.Int, mantissa uint8) (*Decimal, bool) {
+	d.value = new(big.Int).Set(value)
+	d.mantissa = mantissa
+	return d, !fitsWide(value)
+}
+
+func (d *Decimal) SetValue(value *big.Int) *Decimal {
+	d.value = value
+	return d
+}
+
+func (d *Decimal) GetValue() *big.Int {
+	return d.value
+}
+
+func (d *Decimal) SetMantissa(mantissa uint8) *Decimal {
+	d.mantissa = mantissa
+	return d
+}
+
+func (d *Decimal) GetMantissa() uint8 {
+	return d.mantissa
+}
+
+func (d *Decimal) FromString(value string) bool {
+	if value == "" {
+		d.value = big.NewInt(0)
+		d.mantissa = 0
+		return true
+	}
+
+	var ok bool
+	var mantissa uint8 = 0
+	var valBig = new(big.Int)
+	var parts = strings.Split(value, ".")
+
+	if len(parts) > 2 {
+		return false
+	} else if len(parts) == 1 {
+		valBig, ok = valBig.SetString(value, 10)
+	} else {
+		if len(parts[1]) > math.MaxUint8 {
+			return false
+		}
+
+		// drop suffix zeros
+		zerosStart := len(parts[1]) - 1
+		for zerosStart >= 0 && parts[1][zerosStart] == '0' {
+			zerosStart--
+		}
+		parts[1] = parts[1][:zerosStart+1]
+
+		valBig, ok = valBig.SetString(strings.Join(parts, ""), 10)
+		mantissa = uint8(len(parts[1]))
+	}
+
+	if !ok || !fitsWide(valBig) {
+		return false
+	}
+
+	d.value = valBig
+	d.mantissa = mantissa
+
+	return true
+}
+
+// Rescale changes d's scale to mantissa, rounding dropped digits using mode
+// (RoundDown if omitted), and returns d.
+func (d *Decimal) Rescale(mantissa uint8, mode ...RoundingMode) *Decimal {
+	if d == nil {
+		return nil
+	}
+
+	if mantissa == d.mantissa {
+		return d
+	}
+
+	if mantissa > d.mantissa {
+		d.value.Mul(d.value, expScaleBig(int16(mantissa-d.mantissa)))
+		d.mantissa = mantissa
+		return d
+	}
+
+	return d.Round(mantissa, pickRoundingMode(mode))
+}
+
+// RescaleInPlace is Rescale, including its allocations; it exists only for
+// API parity with the default backend.
+func (d *Decimal) RescaleInPlace(mantissa uint8, mode ...RoundingMode) *Decimal {
+	return d.Rescale(mantissa, mode...)
+}
+
+// AddTo sets d = x + y, leaving x and y unmodified, and returns d.
+func (d *Decimal) AddTo(x, y *Decimal) *Decimal {
+	xv := new(big.Int).Set(x.value)
+	yv := new(big.Int).Set(y.value)
+
+	xm, ym := x.mantissa, y.mantissa
+	if ym > xm {
+		xv.Mul(xv, expScaleBig(int16(ym-xm)))
+		xm = ym
+	} else if ym < xm {
+		yv.Mul(yv, expScaleBig(int16(xm-ym)))
+	}
+
+	d.value = new(big.Int).Add(xv, yv)
+	d.mantissa = xm
+
+	return d
+}
+
+// SubTo sets d = x - y, leaving x and y unmodified, and returns d.
+func (d *Decimal) SubTo(x, y *Decimal) *Decimal {
+	xv := new(big.Int).Set(x.value)
+	yv := new(big.Int).Set(y.value)
+
+	xm, ym := x.mantissa, y.mantissa
+	if ym > xm {
+		xv.Mul(xv, expScaleBig(int16(ym-xm)))
+		xm = ym
+	} else if ym < xm {
+		yv.Mul(yv, expScaleBig(int16(xm-ym)))
+	}
+
+	d.value = new(big.Int).Sub(xv, yv)
+	d.mantissa = xm
+
+	return d
+}
+
+// MulTo sets d = x * y, leaving x and y unmodified, and returns d.
+func (d *Decimal) MulTo(x, y *Decimal) *Decimal {
+	xv := new(big.Int).Set(x.value)
+	yv := new(big.Int).Set(y.value)
+
+	xm, ym := x.mantissa, y.mantissa
+	if ym > xm {
+		xv.Mul(xv, expScaleBig(int16(ym-xm)))
+		xm = ym
+	} else if ym < xm {
+		yv.Mul(yv, expScaleBig(int16(xm-ym)))
+		ym = xm
+	}
+
+	d.value = new(big.Int).Mul(xv, yv)
+	d.mantissa = xm + ym
+
+	return d
+}
+
+// DivTo sets d = x / y, rounded to defaultDivScale digits using mode
+// (RoundDown if omitted), or to x's scale if that exceeds y's by
+// defaultDivScale or more. x and y are left unmodified, and d is returned.
+func (d *Decimal) DivTo(x, y *Decimal, mode ...RoundingMode) *Decimal {
+	if y.Eq(Zero) {
+		d.value = big.NewInt(0)
+		d.mantissa = 0
+		return d
+	}
+
+	xv := new(big.Int).Set(x.value)
+	yv := new(big.Int).Set(y.value)
+
+	var scalerest uint8
+	e := int64(x.mantissa) - int64(y.mantissa) - int64(defaultDivScale)
+	if e < 0 {
+		xv.Mul(xv, expScaleBig(int16(-e)))
+		scalerest = defaultDivScale
+	} else {
+		xv.Mul(xv, expScaleBig(int16(y.mantissa)))
+		scalerest = x.mantissa
+	}
+
+	neg := (xv.Sign() < 0) != (yv.Sign() < 0)
+	xvAbs := new(big.Int).Abs(xv)
+	yvAbs := new(big.Int).Abs(yv)
+
+	quotient := new(big.Int)
+	remainder := new(big.Int)
+	quotient.QuoRem(xvAbs, yvAbs, remainder)
+
+	if roundUp(pickRoundingMode(mode), neg, remainder, yvAbs, quotient) {
+		quotient.Add(quotient, big.NewInt(1))
+	}
+	if neg {
+		quotient.Neg(quotient)
+	}
+
+	d.value = quotient
+	d.mantissa = scalerest
+
+	return d
+}
+
+// CmpNoAlloc is cmp, including its copies of both operands; it exists only
+// for API parity with the default backend.
+func (d *Decimal) CmpNoAlloc(y *Decimal) int {
+	return d.cmp(y)
+}
+
+// Reset zeroes d in place for reuse (e.g. after Acquire from the scratch
+// pool) and returns d.
+func (d *Decimal) Reset() *Decimal {
+	d.value.SetInt64(0)
+	d.mantissa = 0
+	return d
+}
+
+func (d *Decimal) ToBig() *big.Int {
+	return new(big.Int).Set(d.value)
+}
+
+func (d *Decimal) String() string {
+	if d == nil || d.value == nil {
+		return "0"
+	}
+
+	return formatBig(d.value, int(d.mantissa))
+}
+
+func (d *Decimal) IsZero() bool {
+	return d.value.Sign() == 0
+}
+
+func NewDecimalFromUint256(value *uint256.Int, mantissa uint8) *Decimal {
+	return &Decimal{
+		value:    value.ToBig(),
+		mantissa: mantissa,
+	}
+}
+
+// NewDecimalFromInt256 interprets value as a two's-complement signed 256-bit
+// integer (as produced by Solidity's int256 or an RLP-decoded signed field)
+// and builds the equivalent Decimal.
+func NewDecimalFromInt256(value *uint256.Int, mantissa uint8) *Decimal {
+	b := value.ToBig()
+
+	signBit := new(big.Int).Lsh(big.NewInt(1), 255)
+	if b.Cmp(signBit) >= 0 {
+		modulus := new(big.Int).Lsh(big.NewInt(1), 256)
+		b = new(big.Int).Sub(b, modulus)
+	}
+
+	return &Decimal{value: b, mantissa: mantissa}
+}
+
+// FromBytes builds a Decimal from a big-endian unsigned magnitude, as found
+// on the wire in binary/RLP/ABI encodings. It fails if data is longer than
+// the backend's word size.
+func FromBytes(data []byte, mantissa uint8) (*Decimal, bool) {
+	if len(data) > wideBits/8 {
+		return nil, false
+	}
+
+	return &Decimal{
+		value:    new(big.Int).SetBytes(data),
+		mantissa: mantissa,
+	}, true
+}
+
+func NewDecimalFromBig(value *big.Int, mantissa uint8) *Decimal {
+	if value == nil {
+		value = new(big.Int)
+	}
+
+	if !fitsWide(value) {
+		return NewDecimalZero()
+	}
+
+	return &Decimal{
+		value:    new(big.Int).Set(value),
+		mantissa: mantissa,
+	}
+}
+
+func NewDecimalFromUint64(value uint64) *Decimal {
+	d, _ := NewDecimalZero().SetFromBig(big.NewInt(int64(value)), 0)
+	return d
+}
+
+func NewDecimalFromFloat64(value float64) *Decimal {
+	val, _ := NewDecimalFromString(strconv.FormatFloat(value, 'f', -1, 64))
+	return val
+}
+
+func NewDecimalZero() *Decimal {
+	return &Decimal{
+		value:    big.NewInt(0),
+		mantissa: 0,
+	}
+}
+
+func NewDecimalOne() *Decimal {
+	return &Decimal{
+		value:    big.NewInt(1),
+		mantissa: 0,
+	}
+}
+
+func NewDecimalFromString(val string) (*Decimal, bool) {
+	d := NewDecimalZero()
+	if !d.FromString(val) {
+		return nil, false
+	}
+
+	return d, true
+}
+
+func NewDecimal(decimal *Decimal) *Decimal {
+	if decimal == nil {
+		decimal = NewDecimalZero()
+	}
+
+	return &Decimal{
+		value:    new(big.Int).Set(decimal.value),
+		mantissa: decimal.mantissa,
+	}
+}
@@ -0,0 +1,7 @@
+//go:build decimal512
+
+package decimal
+
+// wideBits is the signed bit width of the mantissa magnitude backing
+// Decimal when built with the decimal512 tag.
+const wideBits = 512
@@ -0,0 +1,135 @@
+package decimal
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// MarshalBinary implements encoding.BinaryMarshaler as a scale byte, a sign
+// byte, a big-endian uint32 magnitude length, and the big-endian magnitude
+// itself.
+func (d *Decimal) MarshalBinary() ([]byte, error) {
+	value := d.ToBig()
+	mag := new(big.Int).Abs(value).Bytes()
+	if len(mag) > math.MaxUint32 {
+		return nil, fmt.Errorf("decimal: mantissa too large to encode (%d bytes)", len(mag))
+	}
+
+	out := make([]byte, 6+len(mag))
+	out[0] = d.GetMantissa()
+	if value.Sign() < 0 {
+		out[1] = 1
+	}
+	binary.BigEndian.PutUint32(out[2:6], uint32(len(mag)))
+	copy(out[6:], mag)
+
+	return out, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler for the format
+// produced by MarshalBinary.
+func (d *Decimal) UnmarshalBinary(data []byte) error {
+	if len(data) < 6 {
+		return fmt.Errorf("decimal: binary data too short: %d bytes", len(data))
+	}
+
+	scale := data[0]
+	neg := data[1] == 1
+	length := binary.BigEndian.Uint32(data[2:6])
+	if uint32(len(data)-6) != length {
+		return fmt.Errorf("decimal: binary magnitude length mismatch: header says %d, got %d", length, len(data)-6)
+	}
+
+	value := new(big.Int).SetBytes(data[6:])
+	if neg {
+		value.Neg(value)
+	}
+
+	decoded := NewDecimalZero()
+	if _, overflow := decoded.SetFromBig(value, scale); overflow {
+		return fmt.Errorf("decimal: binary magnitude overflows backend: %s", value.String())
+	}
+	*d = *decoded
+
+	return nil
+}
+
+// rlpDecimal is the RLP wire shape for Decimal: sign and magnitude encoded
+// separately, since go-ethereum's rlp package rejects negative *big.Int.
+type rlpDecimal struct {
+	Magnitude *big.Int
+	Neg       bool
+	Mantissa  uint8
+}
+
+// EncodeRLP implements rlp.Encoder, encoding d as the RLP list
+// [magnitude, sign, scale].
+func (d *Decimal) EncodeRLP(w io.Writer) error {
+	value := d.ToBig()
+
+	return rlp.Encode(w, rlpDecimal{
+		Magnitude: new(big.Int).Abs(value),
+		Neg:       value.Sign() < 0,
+		Mantissa:  d.GetMantissa(),
+	})
+}
+
+// DecodeRLP implements rlp.Decoder for the list produced by EncodeRLP.
+func (d *Decimal) DecodeRLP(s *rlp.Stream) error {
+	var raw rlpDecimal
+	if err := s.Decode(&raw); err != nil {
+		return err
+	}
+
+	value := new(big.Int).Set(raw.Magnitude)
+	if raw.Neg {
+		value.Neg(value)
+	}
+
+	decoded := NewDecimalZero()
+	if _, overflow := decoded.SetFromBig(value, raw.Mantissa); overflow {
+		return fmt.Errorf("decimal: RLP magnitude overflows backend: %s", value.String())
+	}
+	*d = *decoded
+
+	return nil
+}
+
+// ToABIFixed encodes d as the 32-byte big-endian word Solidity uses for
+// ufixedMbits x scale, rescaling d to scale digits first. It errors if d is
+// negative (ufixed is unsigned) or the scaled value overflows bits.
+func (d *Decimal) ToABIFixed(bits uint16, scale uint8) ([]byte, error) {
+	if bits == 0 || bits > 256 || bits%8 != 0 {
+		return nil, fmt.Errorf("decimal: invalid ufixed bit width %d", bits)
+	}
+	if d.Sign() < 0 {
+		return nil, fmt.Errorf("decimal: negative value cannot encode as ufixed%dx%d", bits, scale)
+	}
+
+	raw := NewDecimal(d).Rescale(scale).ToBig()
+
+	limit := new(big.Int).Lsh(big.NewInt(1), uint(bits))
+	if raw.Cmp(limit) >= 0 {
+		return nil, fmt.Errorf("decimal: value overflows ufixed%dx%d", bits, scale)
+	}
+
+	out := make([]byte, 32)
+	raw.FillBytes(out)
+
+	return out, nil
+}
+
+// FromABIFixed decodes a 32-byte big-endian ufixedMxN word (as produced by
+// ToABIFixed) into a Decimal with the given scale.
+func FromABIFixed(data []byte, scale uint8) (*Decimal, bool) {
+	if len(data) != 32 {
+		return nil, false
+	}
+
+	return NewDecimalFromBig(new(big.Int).SetBytes(data), scale), true
+}
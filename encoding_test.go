@@ -0,0 +1,81 @@
+package decimal
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+func TestBinaryRoundTrip(t *testing.T) {
+	cases := []string{"0", "123.45", "-123.45", "42"}
+	for _, c := range cases {
+		d := mustDecimal(t, c)
+
+		data, err := d.MarshalBinary()
+		if err != nil {
+			t.Fatalf("MarshalBinary(%s): %v", c, err)
+		}
+
+		var out Decimal
+		if err := out.UnmarshalBinary(data); err != nil {
+			t.Fatalf("UnmarshalBinary(%s): %v", c, err)
+		}
+		if !out.Eq(d) {
+			t.Errorf("round-tripped %s, want %s", out.String(), c)
+		}
+	}
+}
+
+func TestUnmarshalBinaryTooShort(t *testing.T) {
+	if err := new(Decimal).UnmarshalBinary([]byte{1, 2, 3}); err == nil {
+		t.Errorf("UnmarshalBinary of a truncated header should fail")
+	}
+}
+
+func TestRLPRoundTrip(t *testing.T) {
+	cases := []string{"0", "123.45", "-123.45", "42"}
+	for _, c := range cases {
+		d := mustDecimal(t, c)
+
+		var buf bytes.Buffer
+		if err := rlp.Encode(&buf, d); err != nil {
+			t.Fatalf("rlp.Encode(%s): %v", c, err)
+		}
+
+		var out Decimal
+		if err := rlp.Decode(&buf, &out); err != nil {
+			t.Fatalf("rlp.Decode(%s): %v", c, err)
+		}
+		if !out.Eq(d) {
+			t.Errorf("round-tripped %s, want %s", out.String(), c)
+		}
+	}
+}
+
+func TestABIFixedRoundTrip(t *testing.T) {
+	d := mustDecimal(t, "123.456")
+
+	data, err := d.ToABIFixed(256, 6)
+	if err != nil {
+		t.Fatalf("ToABIFixed: %v", err)
+	}
+	if len(data) != 32 {
+		t.Fatalf("ToABIFixed returned %d bytes, want 32", len(data))
+	}
+
+	out, ok := FromABIFixed(data, 6)
+	if !ok {
+		t.Fatalf("FromABIFixed failed")
+	}
+	if !out.Eq(d) {
+		t.Errorf("round-tripped %s, want %s", out.String(), d.String())
+	}
+}
+
+func TestABIFixedRejectsNegative(t *testing.T) {
+	d := mustDecimal(t, "-1")
+	if _, err := d.ToABIFixed(256, 6); err == nil {
+		t.Errorf("ToABIFixed(negative) should have failed")
+	}
+}
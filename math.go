@@ -0,0 +1,241 @@
+package decimal
+
+import "math/big"
+
+// MaxIterations bounds the Taylor/Newton loops in Exp and Ln so a value that
+// fails to converge (or converges too slowly for the requested precision)
+// fails fast instead of spinning.
+const MaxIterations = 200
+
+// ln2Digits is ln(2) to 50 digits, used by Ln's range reduction. Beyond 50
+// digits it is zero-padded, so Ln results that factor out a power of two are
+// accurate to at most about 50 digits whatever precision is requested.
+const ln2Digits = "0.69314718055994530941723212145817656807550013436025"
+
+func pickScale(scale []uint8) uint8 {
+	if len(scale) > 0 {
+		return scale[0]
+	}
+	return defaultDivScale
+}
+
+// mulRescaled computes x*y rescaled to scale digits via math/big rather than
+// Decimal.Mul followed by Rescale. Two operands already carrying `scale`
+// digits of precision multiply to a raw product with up to 2*scale digits,
+// which can briefly exceed the default backend's digit capacity even though
+// the final, rescaled result fits comfortably - as happens repeatedly in
+// Exp's Taylor loop and Ln's range reduction and Newton iteration at guard
+// precisions beyond ~38 digits. Computing the product in math/big sidesteps
+// that transient overflow.
+func mulRescaled(x, y *Decimal, scale uint8) *Decimal {
+	product := new(big.Int).Mul(x.ToBig(), y.ToBig())
+
+	combined := int(x.GetMantissa()) + int(y.GetMantissa())
+	if diff := combined - int(scale); diff > 0 {
+		product.Quo(product, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(diff)), nil))
+	} else if diff < 0 {
+		product.Mul(product, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-diff)), nil))
+	}
+
+	return NewDecimalFromBig(product, scale)
+}
+
+// PowInt sets d to d^n using binary exponentiation (square-and-multiply) and
+// returns d. Negative n computes 1 / d^(-n) at defaultDivScale precision.
+// Each squaring is rescaled back to the working precision (d's own scale,
+// widened to at least defaultDivScale) so mantissa doesn't double every
+// iteration and wrap the uint8 scale.
+func (d *Decimal) PowInt(n int) *Decimal {
+	if n == 0 {
+		*d = *NewDecimalOne()
+		return d
+	}
+
+	neg := n < 0
+	if neg {
+		n = -n
+	}
+
+	scale := d.GetMantissa()
+	if scale < defaultDivScale {
+		scale = defaultDivScale
+	}
+
+	base := NewDecimal(d)
+	result := NewDecimalOne().Rescale(scale)
+	for n > 0 {
+		if n&1 == 1 {
+			result.Mul(base).Rescale(scale, RoundHalfEven)
+		}
+		base.Mul(base).Rescale(scale, RoundHalfEven)
+		n >>= 1
+	}
+
+	if neg {
+		result = NewDecimalOne().Div(result)
+	}
+
+	*d = *result
+	return d
+}
+
+// Pow sets d to d^exp and returns d, honoring scale digits of precision
+// (defaultDivScale if omitted). Negative bases are only supported for
+// integer exponents, in which case PowInt is used directly.
+func (d *Decimal) Pow(exp *Decimal, scale ...uint8) *Decimal {
+	s := pickScale(scale)
+
+	if exp.IsZero() {
+		*d = *NewDecimalOne()
+		return d
+	}
+
+	if d.IsZero() {
+		*d = *NewDecimalZero()
+		return d
+	}
+
+	if d.Sign() < 0 {
+		if !isIntegerDecimal(exp) {
+			// d^exp is not real for a negative base and fractional exponent.
+			*d = *NewDecimalZero()
+			return d
+		}
+		return d.PowInt(int(exp.ToBig().Int64()))
+	}
+
+	guard := s + 10
+	logBase := NewDecimal(d).Ln(guard)
+	product := NewDecimal(exp).Mul(logBase)
+	result := product.Exp(s)
+
+	*d = *result
+	return d
+}
+
+func isIntegerDecimal(v *Decimal) bool {
+	truncated := NewDecimal(v)
+	truncated.Rescale(0)
+	return truncated.Eq(v)
+}
+
+// Sqrt sets d to sqrt(d) and returns d, honoring scale digits of precision
+// (defaultDivScale if omitted). It rescales to 2*scale digits, takes the
+// integer (Newton's method) square root of the scaled mantissa, then drops
+// back to scale digits. Negative d is undefined and sets d to Zero.
+func (d *Decimal) Sqrt(scale ...uint8) *Decimal {
+	s := pickScale(scale)
+
+	if d.Sign() < 0 {
+		*d = *NewDecimalZero()
+		return d
+	}
+	if d.IsZero() {
+		return d
+	}
+
+	workScale := int(s) * 2
+	if workScale > 255 {
+		workScale = 255
+	}
+
+	scaled := NewDecimal(d).Rescale(uint8(workScale))
+	root := new(big.Int).Sqrt(scaled.ToBig())
+
+	*d = *NewDecimalFromBig(root, uint8(workScale/2))
+	return d
+}
+
+// Exp sets d to e^d and returns d, honoring scale digits of precision
+// (defaultDivScale if omitted). It range-reduces d to |d| <= 1 by repeated
+// halving (at most 64 times), sums the Taylor series for the reduced value until a term
+// rounds to zero at scale digits or MaxIterations is reached, then squares
+// back up. Convergence is geometric once |d| < 1, so MaxIterations is only
+// a backstop for degenerate inputs.
+func (d *Decimal) Exp(scale ...uint8) *Decimal {
+	s := pickScale(scale)
+	guard := s + 10
+
+	x := NewDecimal(d).Rescale(guard)
+	one := NewDecimalOne().Rescale(guard)
+	two := NewDecimalFromUint64(2).Rescale(guard)
+
+	halvings := 0
+	for x.CmpAbs(one) > 0 && halvings < 64 {
+		x.Div(two, RoundHalfEven)
+		halvings++
+	}
+
+	sum := NewDecimalOne().Rescale(guard)
+	term := NewDecimalOne().Rescale(guard)
+	for k := 1; k <= MaxIterations; k++ {
+		term = mulRescaled(term, x, guard)
+		term.Div(NewDecimalFromUint64(uint64(k)), RoundHalfEven)
+		sum.Add(term)
+		if NewDecimal(term).Rescale(s).IsZero() {
+			break
+		}
+	}
+
+	for i := 0; i < halvings; i++ {
+		sum.Mul(sum).Rescale(guard, RoundHalfEven)
+	}
+
+	*d = *sum.Rescale(s)
+	return d
+}
+
+// Ln sets d to the natural logarithm of d and returns d, honoring scale
+// digits of precision (defaultDivScale if omitted). It range-reduces d to a
+// value x in [1, 2] by repeated halving/doubling (tracking the power of two
+// factored out, added back via the ln(2) constant), then refines an initial
+// guess with Newton's method on f(y) = e^y - x, which converges quadratically, up
+// to MaxIterations. d <= 0 is undefined and sets d to Zero.
+func (d *Decimal) Ln(scale ...uint8) *Decimal {
+	s := pickScale(scale)
+
+	if d.Sign() <= 0 {
+		*d = *NewDecimalZero()
+		return d
+	}
+
+	guard := s + 10
+	x := NewDecimal(d).Rescale(guard)
+	one := NewDecimalOne().Rescale(guard)
+	two := NewDecimalFromUint64(2).Rescale(guard)
+	ln2, _ := NewDecimalFromString(ln2Digits)
+	ln2.Rescale(guard)
+
+	k := 0
+	for x.Gt(two) {
+		x.Div(two, RoundHalfEven)
+		k++
+	}
+	for x.Lt(one) {
+		x = mulRescaled(x, two, guard)
+		k--
+	}
+
+	y := NewDecimal(x).Sub(one)
+	for i := 0; i < MaxIterations; i++ {
+		negY := NewDecimal(y).Neg()
+		expNegY := negY.Exp(guard)
+		delta := mulRescaled(x, expNegY, guard).Sub(one)
+		y.Add(delta)
+		if delta.Rescale(s).IsZero() {
+			break
+		}
+	}
+
+	kAbs := k
+	if kAbs < 0 {
+		kAbs = -kAbs
+	}
+	kLn2 := mulRescaled(ln2, NewDecimalFromUint64(uint64(kAbs)), guard)
+	if k < 0 {
+		kLn2.Neg()
+	}
+
+	*d = *y.Add(kLn2).Rescale(s)
+	return d
+}
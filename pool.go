@@ -0,0 +1,25 @@
+package decimal
+
+import "sync"
+
+var decimalPool = sync.Pool{
+	New: func() interface{} {
+		return NewDecimalZero()
+	},
+}
+
+// Acquire returns a scratch Decimal equal to Zero from a shared pool, for
+// use as the destination of AddTo/SubTo/MulTo/DivTo in hot paths that can't
+// afford Add/Sub/Mul/Div's per-call allocations. Pair with Release.
+func Acquire() *Decimal {
+	return decimalPool.Get().(*Decimal)
+}
+
+// Release resets d and returns it to the pool for reuse by Acquire. Callers
+// must not use d after calling Release.
+func Release(d *Decimal) {
+	if d == nil {
+		return
+	}
+	decimalPool.Put(d.Reset())
+}
@@ -0,0 +1,100 @@
+package decimal
+
+import "testing"
+
+// priceBench and sizeBench carry different scales (3 vs 2 digits), like a
+// real order book's price and size fields, so the benchmarks below exercise
+// the rescale branch of AddTo/MulTo/DivTo/CmpNoAlloc instead of only
+// the equal-scale fast path.
+var (
+	priceBench = mustDecimalBench("56.789")
+	sizeBench  = mustDecimalBench("12.34")
+)
+
+// assertZeroAllocs fails the benchmark if fn allocates, so a regression in
+// the zero-allocation hot path is caught instead of only reported.
+func assertZeroAllocs(b *testing.B, name string, fn func()) {
+	b.Helper()
+	if allocs := testing.AllocsPerRun(1000, fn); allocs > 0 {
+		b.Fatalf("%s allocates %.0f times per op, want 0", name, allocs)
+	}
+}
+
+// BenchmarkAddTo demonstrates the zero-allocation hot path: a pooled scratch
+// Decimal as the destination of AddTo, as intended for tight loops like
+// order book matching.
+func BenchmarkAddTo(b *testing.B) {
+	x, y := priceBench, sizeBench
+
+	assertZeroAllocs(b, "AddTo", func() {
+		dst := Acquire()
+		dst.AddTo(x, y)
+		Release(dst)
+	})
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dst := Acquire()
+		dst.AddTo(x, y)
+		Release(dst)
+	}
+}
+
+func BenchmarkMulTo(b *testing.B) {
+	x, y := priceBench, sizeBench
+
+	assertZeroAllocs(b, "MulTo", func() {
+		dst := Acquire()
+		dst.MulTo(x, y)
+		Release(dst)
+	})
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dst := Acquire()
+		dst.MulTo(x, y)
+		Release(dst)
+	}
+}
+
+func BenchmarkDivTo(b *testing.B) {
+	x, y := priceBench, sizeBench
+
+	assertZeroAllocs(b, "DivTo", func() {
+		dst := Acquire()
+		dst.DivTo(x, y)
+		Release(dst)
+	})
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dst := Acquire()
+		dst.DivTo(x, y)
+		Release(dst)
+	}
+}
+
+func BenchmarkCmpNoAlloc(b *testing.B) {
+	x, y := priceBench, sizeBench
+
+	assertZeroAllocs(b, "CmpNoAlloc", func() {
+		_ = x.CmpNoAlloc(y)
+	})
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = x.CmpNoAlloc(y)
+	}
+}
+
+func mustDecimalBench(s string) *Decimal {
+	d, ok := NewDecimalFromString(s)
+	if !ok {
+		panic("invalid decimal literal in benchmark: " + s)
+	}
+	return d
+}
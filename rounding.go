@@ -0,0 +1,56 @@
+package decimal
+
+import "fmt"
+
+// RoundingMode controls how Round, Rescale, and Div handle digits dropped
+// past the target scale. Modes follow IEEE 754-2008 §4.3 naming. Shared by
+// every Decimal backend (default, decimal256, decimal512).
+type RoundingMode int
+
+const (
+	// RoundDown truncates toward zero (the historical default behavior).
+	RoundDown RoundingMode = iota
+	// RoundUp rounds away from zero.
+	RoundUp
+	// RoundHalfUp rounds to the nearest value, ties away from zero.
+	RoundHalfUp
+	// RoundHalfDown rounds to the nearest value, ties toward zero.
+	RoundHalfDown
+	// RoundHalfEven rounds to the nearest value, ties to the nearest even digit (banker's rounding).
+	RoundHalfEven
+	// RoundCeiling rounds toward positive infinity.
+	RoundCeiling
+	// RoundFloor rounds toward negative infinity.
+	RoundFloor
+)
+
+func (m RoundingMode) String() string {
+	switch m {
+	case RoundDown:
+		return "RoundDown"
+	case RoundUp:
+		return "RoundUp"
+	case RoundHalfUp:
+		return "RoundHalfUp"
+	case RoundHalfDown:
+		return "RoundHalfDown"
+	case RoundHalfEven:
+		return "RoundHalfEven"
+	case RoundCeiling:
+		return "RoundCeiling"
+	case RoundFloor:
+		return "RoundFloor"
+	default:
+		return fmt.Sprintf("RoundingMode(%d)", int(m))
+	}
+}
+
+// pickRoundingMode returns mode[0] if present, else the default RoundDown.
+// It backs the variadic "mode ...RoundingMode" optional-argument convention
+// used by Rescale and Div.
+func pickRoundingMode(mode []RoundingMode) RoundingMode {
+	if len(mode) > 0 {
+		return mode[0]
+	}
+	return RoundDown
+}
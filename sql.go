@@ -0,0 +1,76 @@
+package decimal
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math/big"
+)
+
+// Value implements driver.Valuer, serializing d as its exact decimal string
+// so that storing it in a NUMERIC/DECIMAL column never round-trips through
+// float and loses precision.
+func (d *Decimal) Value() (driver.Value, error) {
+	if d == nil {
+		return nil, nil
+	}
+
+	return d.String(), nil
+}
+
+// Scan implements sql.Scanner, accepting the value shapes a database/sql
+// driver commonly hands back for a NUMERIC/DECIMAL column: []byte, string,
+// int64, float64, and nil (mapped to Zero).
+func (d *Decimal) Scan(src interface{}) error {
+	if src == nil {
+		*d = *NewDecimalZero()
+		return nil
+	}
+
+	switch v := src.(type) {
+	case []byte:
+		if !d.FromString(string(v)) {
+			return fmt.Errorf("decimal: cannot scan []byte %q into Decimal", v)
+		}
+	case string:
+		if !d.FromString(v) {
+			return fmt.Errorf("decimal: cannot scan string %q into Decimal", v)
+		}
+	case int64:
+		parsed, _ := NewDecimalZero().SetFromBig(big.NewInt(v), 0)
+		*d = *parsed
+	case float64:
+		*d = *NewDecimalFromFloat64(v)
+	default:
+		return fmt.Errorf("decimal: unsupported Scan type %T", src)
+	}
+
+	return nil
+}
+
+// NullDecimal represents a Decimal that may be NULL, analogous to sql.NullString.
+type NullDecimal struct {
+	Decimal *Decimal
+	Valid   bool
+}
+
+// Scan implements sql.Scanner.
+func (n *NullDecimal) Scan(src interface{}) error {
+	if src == nil {
+		n.Decimal, n.Valid = nil, false
+		return nil
+	}
+
+	n.Decimal = NewDecimalZero()
+	n.Valid = true
+
+	return n.Decimal.Scan(src)
+}
+
+// Value implements driver.Valuer.
+func (n NullDecimal) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+
+	return n.Decimal.Value()
+}
@@ -0,0 +1,79 @@
+package decimal
+
+import "testing"
+
+func TestValueScanRoundTrip(t *testing.T) {
+	d := mustDecimal(t, "-42.125")
+
+	value, err := d.Value()
+	if err != nil {
+		t.Fatalf("Value: %v", err)
+	}
+
+	var out Decimal
+	if err := out.Scan(value); err != nil {
+		t.Fatalf("Scan(%v): %v", value, err)
+	}
+	if !out.Eq(d) {
+		t.Errorf("round-tripped %s, want %s", out.String(), d.String())
+	}
+}
+
+func TestScanVariants(t *testing.T) {
+	cases := []struct {
+		name string
+		src  interface{}
+		want string
+	}{
+		{"bytes", []byte("12.34"), "12.34"},
+		{"string", "12.34", "12.34"},
+		{"int64", int64(7), "7"},
+		{"float64", float64(1.5), "1.5"},
+		{"nil", nil, "0"},
+	}
+	for _, c := range cases {
+		var out Decimal
+		if err := out.Scan(c.src); err != nil {
+			t.Fatalf("Scan(%s, %v): %v", c.name, c.src, err)
+		}
+		if !out.Eq(mustDecimal(t, c.want)) {
+			t.Errorf("Scan(%s, %v) = %s, want %s", c.name, c.src, out.String(), c.want)
+		}
+	}
+
+	if err := new(Decimal).Scan(true); err == nil {
+		t.Errorf("Scan(bool) should have failed, unsupported src type")
+	}
+}
+
+func TestNullDecimalRoundTrip(t *testing.T) {
+	var n NullDecimal
+	if err := n.Scan(nil); err != nil {
+		t.Fatalf("Scan(nil): %v", err)
+	}
+	if n.Valid {
+		t.Fatalf("Scan(nil) should leave Valid false")
+	}
+	if value, err := n.Value(); err != nil || value != nil {
+		t.Errorf("Value() of invalid NullDecimal = (%v, %v), want (nil, nil)", value, err)
+	}
+
+	if err := n.Scan("3.5"); err != nil {
+		t.Fatalf("Scan(3.5): %v", err)
+	}
+	if !n.Valid || !n.Decimal.Eq(mustDecimal(t, "3.5")) {
+		t.Fatalf("Scan(3.5) = %+v, want Valid Decimal 3.5", n)
+	}
+
+	value, err := n.Value()
+	if err != nil {
+		t.Fatalf("Value: %v", err)
+	}
+	var out Decimal
+	if err := out.Scan(value); err != nil {
+		t.Fatalf("Scan(%v): %v", value, err)
+	}
+	if !out.Eq(n.Decimal) {
+		t.Errorf("round-tripped %s, want %s", out.String(), n.Decimal.String())
+	}
+}